@@ -73,6 +73,9 @@ M.defaults = {
   -- Automatically enable phantom-err when opening Go files
   auto_enable = false,
 
+  -- Skip auto-enable for generated files (// Code generated ... DO NOT EDIT.)
+  skip_generated = true,
+
   -- Display mode for error blocks:
   -- - "fold": Use folding to completely hide error blocks (most aggressive)
   -- - "compressed": Compress error blocks to single line with overlay text
@@ -91,6 +94,17 @@ M.defaults = {
   -- - "conceal": Keep dimmed with Conceal highlight
   reveal_mode = "normal",
 
+  -- Highlight err identifiers with the PhantomErrVar group (links to DiagnosticWarn)
+  highlight_err_vars = false,
+
+  -- Statusline component, see require('phantom-err').statusline():
+  -- - format: Text with {icon} and {count} placeholders
+  -- - icon: Replaces {icon} ("" to omit)
+  statusline = {
+    format = "{icon} {count} phantom",
+    icon = "⚠",
+  },
+
   -- Debug logging level:
   -- - "error": Only errors
   -- - "warn": Warnings and errors
@@ -113,6 +127,27 @@ function M.setup(opts)
 
   -- Validate boolean options
   M.validate_and_fix_boolean("auto_enable", true)
+  M.validate_and_fix_boolean("skip_generated", true)
+  M.validate_and_fix_boolean("highlight_err_vars", false)
+
+  M.validate_statusline()
+end
+
+function M.validate_statusline()
+  if type(M.options.statusline) ~= "table" then
+    log_warn("config", string.format("Invalid statusline: %s. Using defaults", type(M.options.statusline)))
+    M.options.statusline = vim.deepcopy(M.defaults.statusline)
+    return
+  end
+
+  for _, key in ipairs({ "format", "icon" }) do
+    local value = M.options.statusline[key]
+    if type(value) ~= "string" then
+      local default_value = M.defaults.statusline[key]
+      log_warn("config", string.format("Invalid statusline.%s: %s. Using '%s'", key, type(value), default_value))
+      M.options.statusline[key] = default_value
+    end
+  end
 end
 
 function M.validate_and_fix_option(option_name, valid_values, default_value)
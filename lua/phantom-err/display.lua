@@ -3,6 +3,8 @@ local M = {}
 local config = require("phantom-err.config")
 local state = require("phantom-err.state")
 local namespace = vim.api.nvim_create_namespace("phantom-err")
+-- Separate namespace so err highlights survive clear_conceals and hide/show changes
+local err_vars_namespace = vim.api.nvim_create_namespace("phantom-err-vars")
 
 -- Helper function to preserve cursor position during fold operations
 local function with_cursor_preserved(winid, fn)
@@ -48,6 +50,7 @@ local fold_texts = {}
 
 -- Constants
 local MAX_ASSIGNMENT_DISTANCE = 3 -- Maximum lines between assignment and error block to consider them related
+local ERR_VAR_HIGHLIGHT_PRIORITY = 150 -- Extmark priority for PhantomErrVar highlights
 
 -- Helper function to check if cursor is on a related assignment
 local function is_cursor_on_related_assignment(cursor_row, error_assignments, block_start_row)
@@ -245,6 +248,7 @@ function M.cleanup_buffer_before_close(bufnr)
   pcall(function()
     vim.api.nvim_buf_clear_namespace(bufnr, namespace, 0, -1)
   end)
+  M.clear_error_identifier_highlights(bufnr)
 
   -- Clear all folds we created
   pcall(function()
@@ -641,6 +645,25 @@ function M.dim_inline_block(bufnr, block, hl_group)
   end
 end
 
+-- Highlight err identifiers; priority sits above tree-sitter (100) but below dimming
+-- extmarks (default 4096) so dimmed blocks stay dimmed
+function M.highlight_error_identifiers(bufnr, error_identifiers)
+  M.clear_error_identifier_highlights(bufnr)
+
+  for _, identifier in ipairs(error_identifiers) do
+    pcall(vim.api.nvim_buf_set_extmark, bufnr, err_vars_namespace, identifier.start_row, identifier.start_col, {
+      end_row = identifier.end_row,
+      end_col = identifier.end_col,
+      hl_group = "PhantomErrVar",
+      priority = ERR_VAR_HIGHLIGHT_PRIORITY,
+    })
+  end
+end
+
+function M.clear_error_identifier_highlights(bufnr)
+  pcall(vim.api.nvim_buf_clear_namespace, bufnr, err_vars_namespace, 0, -1)
+end
+
 -- Pure folding with no text (fold mode)
 function M.fold_regular_block(bufnr, block)
   M.hide_error_block_advanced(bufnr, block.start_row, block.end_row, "")
@@ -739,6 +762,11 @@ function _G.phantom_err_get_fold_text()
   return require("phantom-err.display").get_fold_text()
 end
 
+-- Register default highlight groups (users can override them in their colorscheme)
+function M.setup_highlights()
+  vim.api.nvim_set_hl(0, "PhantomErrVar", { link = "DiagnosticWarn", default = true })
+end
+
 -- Set up dimmed highlighting for fold text
 function M.setup_fold_highlighting()
   local opts = config.get()
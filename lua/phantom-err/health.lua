@@ -77,6 +77,19 @@ function M.check()
     )
   end
 
+  -- Check that the queries in queries/go/ are on the runtimepath and compile
+  if go_parser_available then
+    for _, query_name in ipairs({ "phantom_err", "phantom_err_vars" }) do
+      local query_ok, query = pcall(vim.treesitter.query.get, "go", query_name)
+      if query_ok and query then
+        vim.health.ok(string.format("%s query loaded from queries/go/%s.scm", query_name, query_name))
+      else
+        vim.health.error(string.format("Failed to load %s query: %s", query_name, tostring(query)))
+        vim.health.info("Make sure the plugin directory is on your runtimepath")
+      end
+    end
+  end
+
   -- Check plugin configuration
   local config_ok, config = pcall(require, "phantom-err.config")
   if config_ok then
@@ -88,11 +101,13 @@ function M.check()
       vim.health.ok("Configuration is valid")
       vim.health.info(
         string.format(
-          "Current config: auto_enable=%s, mode=%s, dimming_mode=%s, reveal_mode=%s, log_level=%s",
+          "Current config: auto_enable=%s, skip_generated=%s, mode=%s, dimming_mode=%s, reveal_mode=%s, highlight_err_vars=%s, log_level=%s",
           tostring(opts.auto_enable),
+          tostring(opts.skip_generated),
           opts.mode,
           opts.dimming_mode,
           opts.reveal_mode,
+          tostring(opts.highlight_err_vars),
           opts.log_level
         )
       )
@@ -221,9 +236,10 @@ function M.check()
   -- Final summary
   vim.health.start("phantom-err: Usage")
   vim.health.info("Commands available:")
-  vim.health.info("  :PhantomToggle   - Toggle phantom effects")
+  vim.health.info("  :PhantomToggle   - Toggle phantom effects for the buffer")
   vim.health.info("  :PhantomHide     - Hide error blocks")
   vim.health.info("  :PhantomShow     - Show error blocks")
+  vim.health.info("  :PhantomQuickfix - Send error blocks to the quickfix list")
   vim.health.info("  :PhantomHealth   - Run this health check")
   vim.health.info("  :PhantomDebug    - Print debug information")
   vim.health.info("  :PhantomLogLevel - Get or set the log level")
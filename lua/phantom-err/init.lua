@@ -14,15 +14,80 @@ local processing_windows = {}
 -- Track which buffers have autocmds set up to avoid duplicates
 local buffers_with_autocmds = {}
 
+-- One debounce timer per buffer for PhantomErrVar refreshes
+local err_var_timers = {}
+
 -- Timing constants
 local AUTO_ENABLE_DELAY_MS = 100 -- Delay after FileType to ensure file is fully loaded
 local TEXT_CHANGE_DEBOUNCE_MS = 200 -- Debounce delay for text changes to avoid excessive re-parsing
 
+-- Buffers with vim.b.phantom_disabled set, and generated files when skip_generated
+-- is on, are skipped by session-based auto-enable
+local function should_skip_auto_enable(bufnr)
+  if state.is_buffer_disabled(bufnr) then
+    return true
+  end
+  return config.get().skip_generated and parser.is_generated(bufnr)
+end
+
 function M.setup(opts)
   config.setup(opts)
 
   local options = config.get()
 
+  -- Register highlight groups now and again whenever a colorscheme clears them
+  display.setup_highlights()
+  vim.api.nvim_create_autocmd("ColorScheme", {
+    group = vim.api.nvim_create_augroup("phantom_err_highlights", { clear = true }),
+    callback = display.setup_highlights,
+  })
+
+  -- Keep PhantomErrVar highlights current in every Go buffer, independent of hide/show state
+  local err_vars_group = vim.api.nvim_create_augroup("phantom_err_err_vars", { clear = true })
+  if options.highlight_err_vars then
+    -- Restart the buffer's timer on every event so only the last edit triggers a re-parse
+    local function on_err_vars_event(event)
+      local bufnr = event.buf
+      local timer = err_var_timers[bufnr]
+      if not timer then
+        timer = (vim.uv or vim.loop).new_timer()
+        err_var_timers[bufnr] = timer
+      end
+
+      timer:stop()
+      timer:start(
+        TEXT_CHANGE_DEBOUNCE_MS,
+        0,
+        vim.schedule_wrap(function()
+          M.refresh_error_variable_highlights(bufnr)
+        end)
+      )
+    end
+
+    -- FileType covers lazy-loaded setups, which re-fire FileType rather than BufEnter
+    vim.api.nvim_create_autocmd("FileType", {
+      pattern = "go",
+      callback = on_err_vars_event,
+      group = err_vars_group,
+    })
+    vim.api.nvim_create_autocmd({ "BufEnter", "TextChanged", "TextChangedI" }, {
+      pattern = "*.go",
+      callback = on_err_vars_event,
+      group = err_vars_group,
+    })
+    vim.api.nvim_create_autocmd({ "BufDelete", "BufUnload" }, {
+      callback = function(event)
+        local timer = err_var_timers[event.buf]
+        if timer then
+          timer:stop()
+          timer:close()
+          err_var_timers[event.buf] = nil
+        end
+      end,
+      group = err_vars_group,
+    })
+  end
+
   -- Set up session-based auto-enable that works regardless of the auto_enable setting
   local auto_enable_group = vim.api.nvim_create_augroup("phantom_err_auto_enable", { clear = true })
 
@@ -50,6 +115,7 @@ function M.setup(opts)
           vim.api.nvim_win_is_valid(winid)
           and vim.api.nvim_buf_is_valid(bufnr)
           and vim.bo[bufnr].filetype == "go"
+          and not should_skip_auto_enable(bufnr)
           and should_auto_enable_for_session()
         then
           config.log_debug("init", string.format("Auto-enabling phantom-err for new Go file in window %d", winid))
@@ -77,8 +143,10 @@ function M.setup(opts)
         )
       )
 
-      -- Check if this window is already enabled
-      if not state.is_enabled(winid) and vim.bo[bufnr].filetype == "go" then
+      -- Respect buffers the user has explicitly shown, then check if this window is already enabled
+      if should_skip_auto_enable(bufnr) then
+        config.log_debug("init", string.format("Buffer %d is disabled, skipping auto-enable", bufnr))
+      elseif not state.is_enabled(winid) and vim.bo[bufnr].filetype == "go" then
         -- Check if we should auto-enable for this session
         if should_auto_enable_for_session() then
           config.log_debug(
@@ -90,6 +158,7 @@ function M.setup(opts)
               vim.api.nvim_win_is_valid(winid)
               and vim.api.nvim_buf_is_valid(bufnr)
               and vim.bo[bufnr].filetype == "go"
+              and not should_skip_auto_enable(bufnr)
             then
               config.log_debug("init", string.format("Session-based auto-enabling phantom-err for window %d", winid))
               M.enable_window(winid)
@@ -107,6 +176,13 @@ function M.setup(opts)
     end,
     group = auto_enable_group,
   })
+
+  -- Apply highlights to Go buffers loaded before setup, or clear stale ones when the option is off
+  for _, bufnr in ipairs(vim.api.nvim_list_bufs()) do
+    if vim.api.nvim_buf_is_loaded(bufnr) then
+      M.refresh_error_variable_highlights(bufnr)
+    end
+  end
 end
 
 function M.toggle()
@@ -133,21 +209,20 @@ function M.show()
     return
   end
 
-  -- Disable for current window
+  -- Disable every window on this buffer (extmarks and folds are buffer-wide)
+  -- and keep auto-enable from re-hiding it
+  vim.b[bufnr].phantom_disabled = true
   state.set_enabled(winid, false)
 
+  for _, enabled_winid in ipairs(state.get_enabled_windows_for_buffer(bufnr)) do
+    state.set_enabled(enabled_winid, false)
+    M.cleanup_window_autocmds(enabled_winid)
+  end
+
   -- Clean up autocmds for this window
   M.cleanup_window_autocmds(winid)
 
-  -- Check if any other windows are still enabled for this buffer
-  local enabled_windows = state.get_enabled_windows_for_buffer(bufnr)
-  if #enabled_windows == 0 then
-    -- No more windows enabled, clear all conceals
-    display.show_all(bufnr)
-  else
-    -- Other windows still enabled, refresh display based on remaining windows
-    M.refresh_buffer_display(bufnr)
-  end
+  display.show_all(bufnr)
 end
 
 function M.hide()
@@ -158,9 +233,101 @@ function M.hide()
     return
   end
 
+  vim.b[bufnr].phantom_disabled = nil
   M.enable_window(winid)
 end
 
+-- Re-apply PhantomErrVar highlights for a Go buffer
+function M.refresh_error_variable_highlights(bufnr)
+  if not vim.api.nvim_buf_is_valid(bufnr) or vim.bo[bufnr].filetype ~= "go" then
+    return
+  end
+
+  if not config.get().highlight_err_vars then
+    display.clear_error_identifier_highlights(bufnr)
+    return
+  end
+
+  display.highlight_error_identifiers(bufnr, parser.find_error_identifiers(bufnr))
+end
+
+-- Build quickfix items for every error block in a buffer, sorted by line
+function M.get_qflist_items(bufnr)
+  local regular_blocks, inline_blocks = parser.find_error_blocks(bufnr)
+  local items = {}
+
+  local function add_item(start_row, start_col, end_row)
+    local lines = vim.api.nvim_buf_get_lines(bufnr, start_row, end_row + 1, false)
+    table.insert(items, {
+      bufnr = bufnr,
+      lnum = start_row + 1,
+      end_lnum = end_row + 1,
+      col = start_col + 1,
+      text = display.compress_lines(lines),
+    })
+  end
+
+  for _, block in ipairs(regular_blocks) do
+    add_item(block.start_row, block.start_col, block.end_row)
+  end
+
+  for _, block in ipairs(inline_blocks) do
+    local if_line = vim.api.nvim_buf_get_lines(bufnr, block.if_start_row, block.if_start_row + 1, false)[1] or ""
+    local if_col = #(if_line:match("^%s*") or "")
+    add_item(block.if_start_row, if_col, block.if_end_row)
+  end
+
+  table.sort(items, function(a, b)
+    return a.lnum < b.lnum
+  end)
+
+  return items
+end
+
+-- Replace the quickfix list with the error blocks in the current buffer
+function M.to_qflist()
+  local bufnr = vim.api.nvim_get_current_buf()
+
+  if vim.bo[bufnr].filetype ~= "go" then
+    vim.notify("phantom-err: This command only works with Go files", vim.log.levels.WARN)
+    return
+  end
+
+  local items = M.get_qflist_items(bufnr)
+  vim.fn.setqflist({}, " ", { title = "phantom-err", items = items })
+
+  config.log_debug("init", string.format("Added %d error blocks from buffer %d to quickfix list", #items, bufnr))
+  vim.notify(string.format("phantom-err: Added %d error blocks to the quickfix list", #items), vim.log.levels.INFO)
+end
+
+-- Name used in the original feature request; kept so existing mappings work
+M.phantom_to_qflist = M.to_qflist
+
+-- Statusline component showing how many error blocks are hidden in the current window
+function M.statusline()
+  local winid = vim.api.nvim_get_current_win()
+  if not state.is_enabled(winid) then
+    return ""
+  end
+
+  local count = state.get_block_count(vim.api.nvim_win_get_buf(winid))
+  if count == 0 then
+    return ""
+  end
+
+  -- Function replacements keep any "%" in the icon or format literal
+  local opts = config.get().statusline
+  local text = opts.format
+    :gsub("{icon}", function()
+      return opts.icon
+    end)
+    :gsub("{count}", function()
+      return tostring(count)
+    end)
+
+  return vim.trim(text)
+end
+
 -- Enable phantom-err for a specific window
 function M.enable_window(winid)
   -- Prevent recursion
@@ -173,7 +340,15 @@ function M.enable_window(winid)
 
   local success = pcall(function()
     local bufnr = vim.api.nvim_win_get_buf(winid)
+
+    -- Shown buffers stay shown until :PhantomHide clears the flag
+    if state.is_buffer_disabled(bufnr) then
+      config.log_debug("init", string.format("Buffer %d is disabled, not enabling window %d", bufnr, winid))
+      return
+    end
+
     local regular_blocks, inline_blocks, error_assignments = parser.find_error_blocks(bufnr)
+    state.set_block_count(bufnr, #regular_blocks + #inline_blocks)
 
     if #regular_blocks > 0 or #inline_blocks > 0 then
       state.set_enabled(winid, true)
@@ -210,6 +385,10 @@ end
 
 -- Refresh display for all enabled windows viewing a buffer
 function M.refresh_buffer_display(bufnr)
+  if state.is_buffer_disabled(bufnr) then
+    return
+  end
+
   local enabled_windows = state.get_enabled_windows_for_buffer(bufnr)
   if #enabled_windows == 0 then
     return
@@ -228,6 +407,7 @@ function M.refresh_buffer_display(bufnr)
 
   -- Parse blocks once for the buffer
   local regular_blocks, inline_blocks, error_assignments = parser.find_error_blocks(bufnr)
+  state.set_block_count(bufnr, #regular_blocks + #inline_blocks)
 
   -- Use the first enabled window to trigger the display refresh
   -- (display logic will consider all windows' cursor positions)
@@ -2,10 +2,40 @@ local M = {}
 
 local config = require("phantom-err.config")
 
-function M.find_error_blocks(bufnr)
+-- Maximum number of lines to scan for the generated-file header before giving up
+local GENERATED_HEADER_SCAN_LINES = 50
+
+-- Check lines from the top of a Go file for the standard generated-file header
+-- (see `go help generate`), which must appear before the package clause
+function M.is_generated_lines(lines)
+  for i, line in ipairs(lines) do
+    if i > GENERATED_HEADER_SCAN_LINES then
+      break
+    end
+    if line:match("^// Code generated .* DO NOT EDIT%.$") then
+      return true
+    end
+    if line:match("^package%s") then
+      return false
+    end
+  end
+
+  return false
+end
+
+function M.is_generated(bufnr)
+  if not vim.api.nvim_buf_is_valid(bufnr) or not vim.api.nvim_buf_is_loaded(bufnr) then
+    return false
+  end
+
+  return M.is_generated_lines(vim.api.nvim_buf_get_lines(bufnr, 0, GENERATED_HEADER_SCAN_LINES, false))
+end
+
+-- Parse a loaded Go buffer and return the root node of its syntax tree, or nil
+local function get_root(bufnr)
   -- Validate buffer before parsing
   if not vim.api.nvim_buf_is_valid(bufnr) or not vim.api.nvim_buf_is_loaded(bufnr) then
-    return {}, {}, {}
+    return nil
   end
 
   local success, parser = pcall(vim.treesitter.get_parser, bufnr, "go")
@@ -14,7 +44,7 @@ function M.find_error_blocks(bufnr)
     if not success and parser and parser:match("no parser for") then
       config.log_error("parser", "Go tree-sitter parser not available: " .. tostring(parser))
     end
-    return {}, {}, {}
+    return nil
   end
 
   local parse_success, trees = pcall(function()
@@ -22,58 +52,37 @@ function M.find_error_blocks(bufnr)
   end)
   if not parse_success or not trees or #trees == 0 then
     -- Parse errors are usually due to invalid Go syntax - don't spam logs
-    return {}, {}, {}
+    return nil
   end
 
   local tree = trees[1]
   if not tree then
+    return nil
+  end
+
+  return tree:root()
+end
+
+-- Load a query from queries/go/<name>.scm; Neovim caches it after the first load
+local function get_query(name)
+  local success, query = pcall(vim.treesitter.query.get, "go", name)
+  if not success or not query then
+    config.log_error("parser", string.format("Failed to load %s query: %s", name, tostring(query)))
+    return nil
+  end
+  return query
+end
+
+function M.find_error_blocks(bufnr)
+  local root = get_root(bufnr)
+  if not root then
     return {}, {}, {}
   end
 
-  local root = tree:root()
-  local query = vim.treesitter.query.parse(
-    "go",
-    [[
-    ; Match `if err != nil` pattern (regular)
-    (if_statement
-      condition: (binary_expression
-        left: (identifier) @err_var
-        operator: "!="
-        right: (nil)
-      )
-      consequence: (block)
-    ) @if_block
-    (#eq? @err_var "err")
-    
-    ; Match `if nil != err` pattern (reverse order)
-    (if_statement
-      condition: (binary_expression
-        left: (nil)
-        operator: "!="
-        right: (identifier) @err_var_reverse
-      )
-      consequence: (block)
-    ) @if_block_reverse
-    (#eq? @err_var_reverse "err")
-    
-    ; Match error variable assignments (e.g., _, err := someFunc())
-    (assignment_statement
-      left: (expression_list
-        . (identifier)
-        . (identifier) @err_assign
-      )
-    ) @assign_block
-    (#eq? @err_assign "err")
-    
-    ; Match simple error assignments (e.g., err := someFunc())
-    (short_var_declaration
-      left: (expression_list
-        (identifier) @err_simple
-      )
-    ) @simple_assign_block
-    (#eq? @err_simple "err")
-  ]]
-  )
+  local query = get_query("phantom_err")
+  if not query then
+    return {}, {}, {}
+  end
 
   local regular_blocks = {}
   local inline_blocks = {}
@@ -190,5 +199,67 @@ function M.find_error_blocks(bufnr)
   return regular_blocks, inline_blocks, error_assignments
 end
 
+-- Find the ranges of error handling if statements in Go source text, for files
+-- that are not loaded in a buffer
+function M.find_error_block_ranges_in_source(source)
+  local success, parser = pcall(vim.treesitter.get_string_parser, source, "go")
+  if not success or not parser then
+    return {}
+  end
+
+  local parse_success, trees = pcall(function()
+    return parser:parse()
+  end)
+  if not parse_success or not trees or not trees[1] then
+    return {}
+  end
+
+  local query = get_query("phantom_err")
+  if not query then
+    return {}
+  end
+
+  local ranges = {}
+  for id, node in query:iter_captures(trees[1]:root(), source, 0, -1) do
+    local capture_name = query.captures[id]
+    if capture_name == "if_block" or capture_name == "if_block_reverse" then
+      local start_row, start_col, end_row = node:range()
+      table.insert(ranges, {
+        start_row = start_row,
+        start_col = start_col,
+        end_row = end_row,
+      })
+    end
+  end
+
+  return ranges
+end
+
+-- Find every err identifier in a buffer (only used when highlight_err_vars is on)
+function M.find_error_identifiers(bufnr)
+  local root = get_root(bufnr)
+  if not root then
+    return {}
+  end
+
+  local query = get_query("phantom_err_vars")
+  if not query then
+    return {}
+  end
+
+  local error_identifiers = {}
+  for _, node in query:iter_captures(root, bufnr, 0, -1) do
+    local start_row, start_col, end_row, end_col = node:range()
+    table.insert(error_identifiers, {
+      start_row = start_row,
+      start_col = start_col,
+      end_row = end_row,
+      end_col = end_col,
+    })
+  end
+
+  return error_identifiers
+end
+
 return M
 
@@ -6,6 +6,9 @@ local config = require("phantom-err.config")
 local window_states = {}
 local window_cursor_positions = {}
 
+-- Number of error blocks found in each buffer by the last parse
+local buffer_block_counts = {}
+
 -- Track which windows are enabled for phantom-err
 function M.is_enabled(winid)
   if not winid or not vim.api.nvim_win_is_valid(winid) then
@@ -50,6 +53,25 @@ function M.set_cursor_position(winid, cursor_row)
   end
 end
 
+-- Check the buffer-local phantom_disabled flag (Lua true or Vimscript 1)
+function M.is_buffer_disabled(bufnr)
+  if not bufnr or not vim.api.nvim_buf_is_valid(bufnr) then
+    return false
+  end
+
+  local disabled = vim.b[bufnr].phantom_disabled
+  return disabled == true or disabled == 1
+end
+
+-- Track error block counts for a specific buffer
+function M.get_block_count(bufnr)
+  return buffer_block_counts[bufnr] or 0
+end
+
+function M.set_block_count(bufnr, count)
+  buffer_block_counts[bufnr] = count
+end
+
 -- Get all windows that are currently enabled for a specific buffer
 function M.get_enabled_windows_for_buffer(bufnr)
   local enabled_windows = {}
@@ -117,6 +139,8 @@ function M.cleanup_buffer(bufnr)
     M.cleanup_window(winid)
   end
 
+  buffer_block_counts[bufnr] = nil
+
   -- Clean up display effects for this buffer
   -- We need to use a lazy require to avoid circular dependency
   local ok, display = pcall(require, "phantom-err.display")
@@ -0,0 +1,172 @@
+local M = {}
+
+local config = require("phantom-err.config")
+local display = require("phantom-err.display")
+local parser = require("phantom-err.parser")
+
+-- Upper bound on workspace files scanned per picker call, and on directory depth
+local MAX_WORKSPACE_FILES = 2000
+local MAX_WORKSPACE_DEPTH = 20
+
+-- Directory names that are never entered while walking the workspace
+local IGNORED_DIRS = { vendor = true, node_modules = true }
+
+-- Check a directory path relative to the workspace root for vendored code,
+-- node_modules, or hidden directories (.git, .cache, ...)
+local function is_ignored_dir(relative_dir)
+  for part in relative_dir:gmatch("[^/]+") do
+    if IGNORED_DIRS[part] or part:sub(1, 1) == "." then
+      return true
+    end
+  end
+  return false
+end
+
+-- Apply the workspace filters to an open buffer's path; buffers outside root
+-- are checked against their full directory path
+local function is_ignored_buffer(root, bufnr)
+  if config.get().skip_generated and parser.is_generated(bufnr) then
+    return true
+  end
+
+  local name = vim.api.nvim_buf_get_name(bufnr)
+  if name == "" then
+    return false
+  end
+
+  local dir = vim.fs.dirname(vim.fs.normalize(name))
+  if dir == root then
+    return false
+  end
+  if dir:sub(1, #root + 1) == root .. "/" then
+    dir = dir:sub(#root + 2)
+  end
+  return is_ignored_dir(dir)
+end
+
+-- Collect quickfix items for the error blocks in every loaded Go buffer
+local function collect_buffer_items(root, covered_paths)
+  local phantom_err = require("phantom-err")
+  local items = {}
+
+  for _, bufnr in ipairs(vim.api.nvim_list_bufs()) do
+    if vim.api.nvim_buf_is_loaded(bufnr) and vim.bo[bufnr].buflisted and vim.bo[bufnr].filetype == "go" then
+      covered_paths[vim.api.nvim_buf_get_name(bufnr)] = true
+      if not is_ignored_buffer(root, bufnr) then
+        vim.list_extend(items, phantom_err.get_qflist_items(bufnr))
+      end
+    end
+  end
+
+  return items
+end
+
+-- List Go files under root, pruning ignored directories so they are never entered.
+-- Returns the paths and whether MAX_WORKSPACE_FILES cut the walk short
+local function find_workspace_files(root)
+  local paths = {}
+
+  for name, entry_type in
+    vim.fs.dir(root, {
+      depth = MAX_WORKSPACE_DEPTH,
+      skip = function(dir_name)
+        return not is_ignored_dir(dir_name)
+      end,
+    })
+  do
+    if entry_type == "file" and name:match("%.go$") then
+      if #paths >= MAX_WORKSPACE_FILES then
+        return paths, true
+      end
+      table.insert(paths, root .. "/" .. name)
+    end
+  end
+
+  return paths, false
+end
+
+-- Collect quickfix items for Go files under root that are not loaded in a buffer
+local function collect_workspace_items(root, covered_paths)
+  local items = {}
+
+  local paths, truncated = find_workspace_files(root)
+  if truncated then
+    vim.notify(
+      string.format(
+        "phantom-err: Stopped after %d Go files under %s; pass a narrower cwd to the picker",
+        MAX_WORKSPACE_FILES,
+        root
+      ),
+      vim.log.levels.WARN
+    )
+  end
+
+  for _, path in ipairs(paths) do
+    if not covered_paths[path] then
+      local file = io.open(path, "r")
+      if file then
+        local source = file:read("*a")
+        file:close()
+
+        local lines = vim.split(source, "\n", { plain = true })
+        if not (config.get().skip_generated and parser.is_generated_lines(lines)) then
+          for _, range in ipairs(parser.find_error_block_ranges_in_source(source)) do
+            table.insert(items, {
+              filename = path,
+              lnum = range.start_row + 1,
+              end_lnum = range.end_row + 1,
+              col = range.start_col + 1,
+              text = display.compress_lines(vim.list_slice(lines, range.start_row + 1, range.end_row + 1)),
+            })
+          end
+        end
+      end
+    end
+  end
+
+  config.log_debug("telescope", string.format("Scanned %d workspace files under %s", #paths, root))
+  return items
+end
+
+-- Open a Telescope picker listing error blocks in open Go buffers and in the
+-- Go files under opts.cwd (defaults to the current working directory)
+function M.picker(opts)
+  opts = opts or {}
+
+  local has_telescope = pcall(require, "telescope")
+  if not has_telescope then
+    vim.notify("phantom-err: telescope.nvim is not installed", vim.log.levels.WARN)
+    return
+  end
+
+  local pickers = require("telescope.pickers")
+  local finders = require("telescope.finders")
+  local make_entry = require("telescope.make_entry")
+  local conf = require("telescope.config").values
+
+  -- Open buffers come first and reflect unsaved edits; files on disk fill in the rest
+  local root = vim.fs.normalize(opts.cwd or vim.fn.getcwd())
+  local covered_paths = {}
+  local items = collect_buffer_items(root, covered_paths)
+  vim.list_extend(items, collect_workspace_items(root, covered_paths))
+  config.log_debug("telescope", string.format("Collected %d error blocks for picker", #items))
+
+  if #items == 0 then
+    vim.notify("phantom-err: No error blocks found in the workspace", vim.log.levels.INFO)
+    return
+  end
+
+  pickers
+    .new(opts, {
+      prompt_title = "Phantom Errors",
+      finder = finders.new_table({
+        results = items,
+        entry_maker = opts.entry_maker or make_entry.gen_from_quickfix(opts),
+      }),
+      previewer = conf.qflist_previewer(opts),
+      sorter = conf.generic_sorter(opts),
+    })
+    :find()
+end
+
+return M
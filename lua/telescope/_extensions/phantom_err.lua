@@ -0,0 +1,5 @@
+return require("telescope").register_extension({
+  exports = {
+    phantom_err = require("phantom-err.telescope").picker,
+  },
+})
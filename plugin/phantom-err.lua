@@ -31,6 +31,10 @@ vim.api.nvim_create_user_command("PhantomHide", safe_command(phantom_err.hide, "
   desc = "Hide error blocks (enable phantom effects)",
 })
 
+vim.api.nvim_create_user_command("PhantomQuickfix", safe_command(phantom_err.to_qflist, "quickfix"), {
+  desc = "Send error blocks in the current buffer to the quickfix list",
+})
+
 -- Health check command for easier discovery
 vim.api.nvim_create_user_command("PhantomHealth", function()
   vim.cmd("checkhealth phantom-err")
@@ -90,6 +94,7 @@ vim.api.nvim_create_user_command("PhantomDebug", function()
   print("Current buffer: " .. current_buf)
   print("Buffer filetype: " .. vim.bo[current_buf].filetype)
   print("Window enabled: " .. tostring(is_enabled))
+  print("Buffer disabled: " .. tostring(state.is_buffer_disabled(current_buf)))
   print("Cursor position: " .. cursor_pos)
 
   local enabled_windows = state.get_enabled_windows_for_buffer(current_buf)
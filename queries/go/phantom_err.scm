@@ -0,0 +1,38 @@
+;; Match `if err != nil` pattern (regular)
+(if_statement
+  condition: (binary_expression
+    left: (identifier) @err_var
+    operator: "!="
+    right: (nil)
+  )
+  consequence: (block)
+) @if_block
+(#eq? @err_var "err")
+
+;; Match `if nil != err` pattern (reverse order)
+(if_statement
+  condition: (binary_expression
+    left: (nil)
+    operator: "!="
+    right: (identifier) @err_var_reverse
+  )
+  consequence: (block)
+) @if_block_reverse
+(#eq? @err_var_reverse "err")
+
+;; Match error variable assignments (e.g., _, err := someFunc())
+(assignment_statement
+  left: (expression_list
+    . (identifier)
+    . (identifier) @err_assign
+  )
+) @assign_block
+(#eq? @err_assign "err")
+
+;; Match simple error assignments (e.g., err := someFunc())
+(short_var_declaration
+  left: (expression_list
+    (identifier) @err_simple
+  )
+) @simple_assign_block
+(#eq? @err_simple "err")
@@ -0,0 +1,3 @@
+;; Match every err identifier (for PhantomErrVar highlighting)
+((identifier) @err_identifier
+  (#eq? @err_identifier "err"))